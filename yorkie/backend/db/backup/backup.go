@@ -0,0 +1,74 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package backup exports the full state of a db.DB into a single portable,
+// gzip'd tar archive and reimports it into a (possibly different) db.DB
+// backend. It is meant for migrating between the in-memory and Mongo
+// backends, snapshotting a staging server for reproduction, or shipping a
+// disaster-recovery bundle.
+//
+// The `yorkie backup` / `yorkie restore` subcommands in yorkie/cmd are thin
+// wrappers around Dump and Restore.
+package backup
+
+import "errors"
+
+// schemaVersion is bumped whenever the archive layout changes in a way
+// that isn't backward compatible with Restore.
+const schemaVersion = 1
+
+var (
+	// ErrMissingManifest is returned when an archive has no manifest.json,
+	// or the manifest references a table file the archive doesn't have.
+	ErrMissingManifest = errors.New("backup: missing manifest")
+
+	// ErrChecksumMismatch is returned by Restore when a table's contents
+	// don't match the checksum recorded in the archive's manifest.
+	ErrChecksumMismatch = errors.New("backup: checksum mismatch")
+
+	// ErrUnsupportedSchemaVersion is returned by Restore when an archive's
+	// manifest.json declares a schema_version this build of Restore doesn't
+	// know how to read.
+	ErrUnsupportedSchemaVersion = errors.New("backup: unsupported schema version")
+)
+
+// DumpOptions configures what Dump exports.
+type DumpOptions struct {
+	// KeyPrefix, if set, restricts the export to documents whose key has
+	// this prefix.
+	KeyPrefix string
+
+	// FromServerSeq and ToServerSeq restrict the exported change log of
+	// each document to that range. ToServerSeq of zero means "up to the
+	// document's current ServerSeq".
+	FromServerSeq uint64
+	ToServerSeq   uint64
+}
+
+// RestoreOptions configures how Restore imports an archive.
+type RestoreOptions struct {
+	// Overwrite allows Restore to replace a DocInfo that already exists in
+	// the destination database. Without it, Restore fails as soon as it
+	// finds one.
+	Overwrite bool
+}
+
+// manifest is the archive's manifest.json.
+type manifest struct {
+	SchemaVersion int               `json:"schema_version"`
+	SourceBackend string            `json:"source_backend"`
+	Checksums     map[string]string `json:"checksums"`
+}
@@ -0,0 +1,262 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+// changeWindowSize bounds how many ChangeInfos Dump loads into memory at
+// once per document, so a document with a very long history doesn't blow
+// past available memory.
+const changeWindowSize = 1000
+
+// snapshotRecord is how a SnapshotInfo and its reassembled payload are
+// written to snapshots.jsonl. encoding/json base64-encodes the Payload
+// field, so it round-trips as plain text.
+type snapshotRecord struct {
+	*db.SnapshotInfo
+	Payload []byte `json:"payload"`
+}
+
+// Dump streams a gzip'd tar archive of source's full state to w: one NDJSON
+// file per table plus a manifest.json recording the schema version, source
+// backend, and a per-table checksum.
+func Dump(ctx context.Context, source db.DB, w io.Writer, opts DumpOptions) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	checksums := map[string]string{}
+
+	docs, err := source.ListDocInfos(ctx, opts.KeyPrefix)
+	if err != nil {
+		return err
+	}
+
+	if err := dumpTable(tw, checksums, "clients.jsonl", func(enc *json.Encoder) error {
+		clients, err := source.ListClientInfos(ctx)
+		if err != nil {
+			return err
+		}
+		for _, clientInfo := range clients {
+			if err := enc.Encode(clientInfo); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("dump clients: %w", err)
+	}
+
+	if err := dumpTable(tw, checksums, "documents.jsonl", func(enc *json.Encoder) error {
+		for _, docInfo := range docs {
+			if err := enc.Encode(docInfo); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("dump documents: %w", err)
+	}
+
+	if err := dumpTable(tw, checksums, "changes.jsonl", func(enc *json.Encoder) error {
+		return dumpChanges(ctx, source, enc, docs, opts)
+	}); err != nil {
+		return fmt.Errorf("dump changes: %w", err)
+	}
+
+	if err := dumpTable(tw, checksums, "snapshots.jsonl", func(enc *json.Encoder) error {
+		return dumpSnapshots(ctx, source, enc, docs)
+	}); err != nil {
+		return fmt.Errorf("dump snapshots: %w", err)
+	}
+
+	if err := dumpTable(tw, checksums, "synced_seqs.jsonl", func(enc *json.Encoder) error {
+		return dumpSyncedSeqs(ctx, source, enc, docs)
+	}); err != nil {
+		return fmt.Errorf("dump synced seqs: %w", err)
+	}
+
+	body, err := json.MarshalIndent(manifest{
+		SchemaVersion: schemaVersion,
+		SourceBackend: fmt.Sprintf("%T", source),
+		Checksums:     checksums,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeTarEntry(tw, "manifest.json", body); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func dumpChanges(
+	ctx context.Context,
+	source db.DB,
+	enc *json.Encoder,
+	docs []*db.DocInfo,
+	opts DumpOptions,
+) error {
+	for _, docInfo := range docs {
+		to := opts.ToServerSeq
+		if to == 0 || to > docInfo.ServerSeq {
+			to = docInfo.ServerSeq
+		}
+
+		for from := opts.FromServerSeq; from <= to; {
+			end := from + changeWindowSize - 1
+			if end > to {
+				end = to
+			}
+
+			infos, err := source.FindChangeInfosBetweenServerSeqs(ctx, docInfo.ID, from, end)
+			if err != nil {
+				return err
+			}
+			for _, info := range infos {
+				if info.DocID != docInfo.ID {
+					continue
+				}
+				if err := enc.Encode(info); err != nil {
+					return err
+				}
+			}
+
+			from = end + 1
+		}
+	}
+	return nil
+}
+
+func dumpSnapshots(ctx context.Context, source db.DB, enc *json.Encoder, docs []*db.DocInfo) error {
+	for _, docInfo := range docs {
+		snapshotInfos, err := source.ListSnapshotInfos(ctx, docInfo.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, snapshotInfo := range snapshotInfos {
+			payload, err := readSnapshotPayload(ctx, source, snapshotInfo.ID)
+			if err != nil {
+				return err
+			}
+
+			if err := enc.Encode(snapshotRecord{SnapshotInfo: snapshotInfo, Payload: payload}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readSnapshotPayload(ctx context.Context, source db.DB, snapshotID db.ID) ([]byte, error) {
+	r, err := source.OpenSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+func dumpSyncedSeqs(ctx context.Context, source db.DB, enc *json.Encoder, docs []*db.DocInfo) error {
+	for _, docInfo := range docs {
+		infos, err := source.ListSyncedSeqInfos(ctx, docInfo.ID)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			if err := enc.Encode(info); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dumpTable spools one table's NDJSON to a temp file so its size and
+// checksum are known before writing the tar header, then streams it into
+// the archive as a single entry. Spooling happens per table, not for the
+// whole archive, and to disk rather than memory, which is what keeps
+// Dump's memory use bounded regardless of database size.
+func dumpTable(
+	tw *tar.Writer,
+	checksums map[string]string,
+	name string,
+	write func(enc *json.Encoder) error,
+) error {
+	spool, err := os.CreateTemp("", "yorkie-dump-*.jsonl")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+
+	hasher := sha256.New()
+	enc := json.NewEncoder(io.MultiWriter(spool, hasher))
+	if err := write(enc); err != nil {
+		return err
+	}
+	checksums[name] = hex.EncodeToString(hasher.Sum(nil))
+
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0o644,
+		Size: size,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, spool)
+	return err
+}
+
+func writeTarEntry(tw *tar.Writer, name string, body []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0o644,
+		Size: int64(len(body)),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(body)
+	return err
+}
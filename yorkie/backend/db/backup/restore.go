@@ -0,0 +1,230 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+// maxLineSize bounds a single NDJSON line, which is dominated by a
+// snapshot's base64-encoded payload.
+const maxLineSize = 64 * 1024 * 1024
+
+// Restore reads an archive produced by Dump and reimports it into dest,
+// validating every table's checksum against the archive's manifest before
+// writing anything.
+func Restore(ctx context.Context, dest db.DB, r io.Reader, opts RestoreOptions) error {
+	tables, man, err := readArchive(r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, t := range tables {
+			_ = t.Close()
+			_ = os.Remove(t.Name())
+		}
+	}()
+
+	if man.SchemaVersion != schemaVersion {
+		return fmt.Errorf(
+			"%s: archive is schema version %d, this build supports %d: %w",
+			"manifest.json", man.SchemaVersion, schemaVersion, ErrUnsupportedSchemaVersion,
+		)
+	}
+	if err := verifyChecksums(tables, man); err != nil {
+		return err
+	}
+
+	if err := restoreClients(ctx, dest, tables["clients.jsonl"]); err != nil {
+		return fmt.Errorf("restore clients: %w", err)
+	}
+	if err := restoreDocuments(ctx, dest, tables["documents.jsonl"], opts); err != nil {
+		return fmt.Errorf("restore documents: %w", err)
+	}
+	if err := restoreChanges(ctx, dest, tables["changes.jsonl"]); err != nil {
+		return fmt.Errorf("restore changes: %w", err)
+	}
+	if err := restoreSnapshots(ctx, dest, tables["snapshots.jsonl"]); err != nil {
+		return fmt.Errorf("restore snapshots: %w", err)
+	}
+	if err := restoreSyncedSeqs(ctx, dest, tables["synced_seqs.jsonl"]); err != nil {
+		return fmt.Errorf("restore synced seqs: %w", err)
+	}
+
+	return nil
+}
+
+// spooledTable is one table entry from the archive, spooled to a temp file
+// with its checksum computed as it was written, so Restore never holds a
+// whole table (dominated by base64 snapshot payloads) in memory at once.
+type spooledTable struct {
+	*os.File
+	checksum string
+}
+
+// readArchive spools every table entry in r to a temp file and returns them
+// keyed by name, along with the small, fixed-size manifest.json read
+// directly into memory. manifest.json is written last by Dump, so its
+// checksums can only be checked against tables already spooled by the time
+// it's read; verifyChecksums does that comparison afterward.
+func readArchive(r io.Reader) (map[string]*spooledTable, manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, manifest{}, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	tables := make(map[string]*spooledTable)
+	var man manifest
+	sawManifest := false
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, manifest{}, err
+		}
+
+		if hdr.Name == "manifest.json" {
+			body, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, manifest{}, err
+			}
+			if err := json.Unmarshal(body, &man); err != nil {
+				return nil, manifest{}, err
+			}
+			sawManifest = true
+			continue
+		}
+
+		spool, err := os.CreateTemp("", "yorkie-restore-*.jsonl")
+		if err != nil {
+			return nil, manifest{}, err
+		}
+		hasher := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(spool, hasher), tr); err != nil {
+			return nil, manifest{}, err
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return nil, manifest{}, err
+		}
+		tables[hdr.Name] = &spooledTable{File: spool, checksum: hex.EncodeToString(hasher.Sum(nil))}
+	}
+
+	if !sawManifest {
+		return nil, manifest{}, ErrMissingManifest
+	}
+	return tables, man, nil
+}
+
+func verifyChecksums(tables map[string]*spooledTable, man manifest) error {
+	for name, want := range man.Checksums {
+		table, ok := tables[name]
+		if !ok {
+			return fmt.Errorf("%s: %w", name, ErrMissingManifest)
+		}
+		if table.checksum != want {
+			return fmt.Errorf("%s: %w", name, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
+func restoreClients(ctx context.Context, dest db.DB, table *spooledTable) error {
+	return forEachLine(table, func(line []byte) error {
+		var clientInfo db.ClientInfo
+		if err := json.Unmarshal(line, &clientInfo); err != nil {
+			return err
+		}
+		return dest.InsertClientInfo(ctx, &clientInfo)
+	})
+}
+
+func restoreDocuments(ctx context.Context, dest db.DB, table *spooledTable, opts RestoreOptions) error {
+	return forEachLine(table, func(line []byte) error {
+		var docInfo db.DocInfo
+		if err := json.Unmarshal(line, &docInfo); err != nil {
+			return err
+		}
+		return dest.InsertDocInfo(ctx, &docInfo, opts.Overwrite)
+	})
+}
+
+func restoreChanges(ctx context.Context, dest db.DB, table *spooledTable) error {
+	return forEachLine(table, func(line []byte) error {
+		var changeInfo db.ChangeInfo
+		if err := json.Unmarshal(line, &changeInfo); err != nil {
+			return err
+		}
+		return dest.InsertChangeInfo(ctx, &changeInfo)
+	})
+}
+
+func restoreSnapshots(ctx context.Context, dest db.DB, table *spooledTable) error {
+	return forEachLine(table, func(line []byte) error {
+		var record snapshotRecord
+		record.SnapshotInfo = &db.SnapshotInfo{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			return err
+		}
+		return dest.InsertSnapshotInfo(ctx, record.SnapshotInfo, record.Payload)
+	})
+}
+
+func restoreSyncedSeqs(ctx context.Context, dest db.DB, table *spooledTable) error {
+	return forEachLine(table, func(line []byte) error {
+		var syncedSeqInfo db.SyncedSeqInfo
+		if err := json.Unmarshal(line, &syncedSeqInfo); err != nil {
+			return err
+		}
+		return dest.InsertSyncedSeqInfo(ctx, &syncedSeqInfo)
+	})
+}
+
+func forEachLine(table *spooledTable, fn func(line []byte) error) error {
+	if _, err := table.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(table)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
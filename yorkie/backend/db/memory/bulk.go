@@ -0,0 +1,115 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+// The Insert* methods below write a fully-formed record as-is, preserving
+// its ID rather than minting a new one. They exist for bulk loaders such as
+// backup.Restore that need to recreate records exactly as another backend
+// exported them, including the cross-references between them (a
+// ChangeInfo's DocID, a SnapshotInfo's DocID, and so on).
+
+// InsertClientInfo inserts the given ClientInfo as-is.
+func (d *DB) InsertClientInfo(ctx context.Context, clientInfo *db.ClientInfo) error {
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	if err := txn.Insert(tblClients, clientInfo); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
+}
+
+// InsertDocInfo inserts the given DocInfo as-is, failing with
+// db.ErrDocumentNotFound's sibling db.ErrConflictOnUpdate if a document
+// with the same key already exists and overwrite is false.
+func (d *DB) InsertDocInfo(ctx context.Context, docInfo *db.DocInfo, overwrite bool) error {
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	if !overwrite {
+		raw, err := txn.First(tblDocuments, "key", docInfo.Key)
+		if err != nil {
+			return err
+		}
+		if raw != nil {
+			return fmt.Errorf("%s: %w", docInfo.Key, db.ErrConflictOnUpdate)
+		}
+	}
+
+	if err := txn.Insert(tblDocuments, docInfo); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
+}
+
+// InsertChangeInfo inserts the given ChangeInfo as-is.
+func (d *DB) InsertChangeInfo(ctx context.Context, changeInfo *db.ChangeInfo) error {
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	if err := txn.Insert(tblChanges, changeInfo); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
+}
+
+// InsertSnapshotInfo inserts the given SnapshotInfo as-is, chunking payload
+// under the same SnapshotInfo.ID it was exported with. snapshotInfo's
+// TotalSize and NumChunks are recomputed from the chunks this backend
+// actually writes rather than trusted as exported, since the source backend
+// may have chunked payload at a different size; Hash is payload-derived and
+// backend-independent, so it's kept as exported and still gets verified
+// against the reassembled bytes on read.
+func (d *DB) InsertSnapshotInfo(ctx context.Context, snapshotInfo *db.SnapshotInfo, payload []byte) error {
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	chunks, totalSize, _ := splitSnapshotPayload(payload, db.DefaultSnapshotChunkSize)
+	snapshotInfo.TotalSize = totalSize
+	snapshotInfo.NumChunks = uint32(len(chunks))
+
+	if err := txn.Insert(tblSnapshots, snapshotInfo); err != nil {
+		return err
+	}
+	if err := d.writeSnapshotChunks(txn, snapshotInfo.ID, chunks); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
+}
+
+// InsertSyncedSeqInfo inserts the given SyncedSeqInfo as-is.
+func (d *DB) InsertSyncedSeqInfo(ctx context.Context, syncedSeqInfo *db.SyncedSeqInfo) error {
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	if err := txn.Insert(tblSyncedSeqs, syncedSeqInfo); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/yorkie-team/yorkie/api/converter"
 	"github.com/yorkie-team/yorkie/pkg/document"
 	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/crdt"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 	"github.com/yorkie-team/yorkie/yorkie/backend/db"
 )
@@ -134,6 +135,20 @@ func (d *DB) UpdateClientInfoAfterPushPull(
 	ctx context.Context,
 	clientInfo *db.ClientInfo,
 	docInfo *db.DocInfo,
+) error {
+	return d.UpdateClientInfoAfterPushPullTx(ctx, nil, clientInfo, docInfo)
+}
+
+// UpdateClientInfoAfterPushPullTx is the same as UpdateClientInfoAfterPushPull,
+// but binds to the given sot (a Snapshot or Txn) instead of opening its
+// own, so callers can compose it with CreateChangeInfosTx and
+// updateSyncedSeqTx into a single isolated PushPull. Since it mutates,
+// binding it to a Snapshot fails with db.ErrReadOnlyTxn.
+func (d *DB) UpdateClientInfoAfterPushPullTx(
+	ctx context.Context,
+	sot db.SnapshotOrTxn,
+	clientInfo *db.ClientInfo,
+	docInfo *db.DocInfo,
 ) error {
 	clientDocInfo := clientInfo.Documents[docInfo.ID]
 	attached, err := clientInfo.IsAttached(docInfo.ID)
@@ -141,8 +156,14 @@ func (d *DB) UpdateClientInfoAfterPushPull(
 		return err
 	}
 
-	txn := d.db.Txn(true)
-	defer txn.Abort()
+	memTxn, owns, err := d.boundTxn(sot, true)
+	if err != nil {
+		return err
+	}
+	if owns {
+		defer memTxn.Abort()
+	}
+	txn := memTxn
 
 	raw, err := txn.First(tblClients, "id", string(clientInfo.ID))
 	if err != nil {
@@ -184,7 +205,9 @@ func (d *DB) UpdateClientInfoAfterPushPull(
 	if err := txn.Insert(tblClients, loaded); err != nil {
 		return err
 	}
-	txn.Commit()
+	if owns {
+		txn.Commit()
+	}
 
 	return nil
 }
@@ -196,8 +219,27 @@ func (d *DB) FindDocInfoByKey(
 	bsonDocKey string,
 	createDocIfNotExist bool,
 ) (*db.DocInfo, error) {
-	txn := d.db.Txn(true)
-	defer txn.Abort()
+	return d.FindDocInfoByKeyTx(ctx, nil, clientInfo, bsonDocKey, createDocIfNotExist)
+}
+
+// FindDocInfoByKeyTx is the same as FindDocInfoByKey, but binds to the
+// given sot (a Snapshot or Txn) instead of opening its own. Since it may
+// create a doc, binding it to a Snapshot fails with db.ErrReadOnlyTxn.
+func (d *DB) FindDocInfoByKeyTx(
+	ctx context.Context,
+	sot db.SnapshotOrTxn,
+	clientInfo *db.ClientInfo,
+	bsonDocKey string,
+	createDocIfNotExist bool,
+) (*db.DocInfo, error) {
+	memTxn, owns, err := d.boundTxn(sot, true)
+	if err != nil {
+		return nil, err
+	}
+	if owns {
+		defer memTxn.Abort()
+	}
+	txn := memTxn
 
 	raw, err := txn.First(tblDocuments, "key", bsonDocKey)
 	if err != nil {
@@ -221,7 +263,9 @@ func (d *DB) FindDocInfoByKey(
 		if err := txn.Insert(tblDocuments, docInfo); err != nil {
 			return nil, err
 		}
-		txn.Commit()
+		if owns {
+			txn.Commit()
+		}
 	} else {
 		docInfo = raw.(*db.DocInfo).DeepCopy()
 	}
@@ -236,8 +280,29 @@ func (d *DB) CreateChangeInfos(
 	initialServerSeq uint64,
 	changes []*change.Change,
 ) error {
-	txn := d.db.Txn(true)
-	defer txn.Abort()
+	return d.CreateChangeInfosTx(ctx, nil, docInfo, initialServerSeq, changes)
+}
+
+// CreateChangeInfosTx is the same as CreateChangeInfos, but binds to the
+// given sot (a Snapshot or Txn) instead of opening its own, so it can be
+// composed with UpdateClientInfoAfterPushPullTx and updateSyncedSeqTx into
+// a single isolated PushPull. Since it mutates, binding it to a Snapshot
+// fails with db.ErrReadOnlyTxn.
+func (d *DB) CreateChangeInfosTx(
+	ctx context.Context,
+	sot db.SnapshotOrTxn,
+	docInfo *db.DocInfo,
+	initialServerSeq uint64,
+	changes []*change.Change,
+) error {
+	memTxn, owns, err := d.boundTxn(sot, true)
+	if err != nil {
+		return err
+	}
+	if owns {
+		defer memTxn.Abort()
+	}
+	txn := memTxn
 
 	for _, cn := range changes {
 		encodedOperations, err := db.EncodeOperations(cn.Operations())
@@ -254,6 +319,7 @@ func (d *DB) CreateChangeInfos(
 			Lamport:    cn.ID().Lamport(),
 			Message:    cn.Message(),
 			Operations: encodedOperations,
+			CreatedAt:  gotime.Now(),
 		}); err != nil {
 			return err
 		}
@@ -277,7 +343,9 @@ func (d *DB) CreateChangeInfos(
 		return err
 	}
 
-	txn.Commit()
+	if owns {
+		txn.Commit()
+	}
 	return nil
 }
 
@@ -288,7 +356,19 @@ func (d *DB) FindChangesBetweenServerSeqs(
 	from uint64,
 	to uint64,
 ) ([]*change.Change, error) {
-	infos, err := d.FindChangeInfosBetweenServerSeqs(ctx, docID, from, to)
+	return d.FindChangesBetweenServerSeqsTx(ctx, nil, docID, from, to)
+}
+
+// FindChangesBetweenServerSeqsTx is the same as FindChangesBetweenServerSeqs,
+// but binds to the given sot (a Snapshot or Txn) instead of opening its own.
+func (d *DB) FindChangesBetweenServerSeqsTx(
+	ctx context.Context,
+	sot db.SnapshotOrTxn,
+	docID db.ID,
+	from uint64,
+	to uint64,
+) ([]*change.Change, error) {
+	infos, err := d.FindChangeInfosBetweenServerSeqsTx(ctx, sot, docID, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -312,8 +392,26 @@ func (d *DB) FindChangeInfosBetweenServerSeqs(
 	from uint64,
 	to uint64,
 ) ([]*db.ChangeInfo, error) {
-	txn := d.db.Txn(false)
-	defer txn.Abort()
+	return d.FindChangeInfosBetweenServerSeqsTx(ctx, nil, docID, from, to)
+}
+
+// FindChangeInfosBetweenServerSeqsTx is the same as
+// FindChangeInfosBetweenServerSeqs, but binds to the given sot (a Snapshot
+// or Txn) instead of opening its own.
+func (d *DB) FindChangeInfosBetweenServerSeqsTx(
+	ctx context.Context,
+	sot db.SnapshotOrTxn,
+	docID db.ID,
+	from uint64,
+	to uint64,
+) ([]*db.ChangeInfo, error) {
+	txn, owns, err := d.boundTxn(sot, false)
+	if err != nil {
+		return nil, err
+	}
+	if owns {
+		defer txn.Abort()
+	}
 
 	var infos []*db.ChangeInfo
 
@@ -351,17 +449,46 @@ func (d *DB) CreateSnapshotInfo(
 	txn := d.db.Txn(true)
 	defer txn.Abort()
 
-	if err := txn.Insert(tblSnapshots, &db.SnapshotInfo{
+	if err := d.insertChunkedSnapshot(
+		txn,
+		docID,
+		doc.Checkpoint().ServerSeq,
+		snapshot,
+		db.DefaultSnapshotChunkSize,
+	); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
+}
+
+// insertChunkedSnapshot stores a SnapshotInfo row, with its TotalSize,
+// NumChunks, and Hash already computed, plus the chunked payload backing
+// it, all within the given transaction. chunkSize <= 0 falls back to
+// db.DefaultSnapshotChunkSize.
+func (d *DB) insertChunkedSnapshot(
+	txn *memdb.Txn,
+	docID db.ID,
+	serverSeq uint64,
+	payload []byte,
+	chunkSize int,
+) error {
+	chunks, totalSize, hash := splitSnapshotPayload(payload, chunkSize)
+
+	snapshotInfo := &db.SnapshotInfo{
 		ID:        newID(),
 		DocID:     docID,
-		ServerSeq: doc.Checkpoint().ServerSeq,
-		Snapshot:  snapshot,
+		ServerSeq: serverSeq,
 		CreatedAt: gotime.Now(),
-	}); err != nil {
+		TotalSize: totalSize,
+		NumChunks: uint32(len(chunks)),
+		Hash:      hash,
+	}
+	if err := txn.Insert(tblSnapshots, snapshotInfo); err != nil {
 		return err
 	}
-	txn.Commit()
-	return nil
+
+	return d.writeSnapshotChunks(txn, snapshotInfo.ID, chunks)
 }
 
 // FindLastSnapshotInfo finds the last snapshot of the given document.
@@ -369,8 +496,23 @@ func (d *DB) FindLastSnapshotInfo(
 	ctx context.Context,
 	docID db.ID,
 ) (*db.SnapshotInfo, error) {
-	txn := d.db.Txn(false)
-	defer txn.Abort()
+	return d.FindLastSnapshotInfoTx(ctx, nil, docID)
+}
+
+// FindLastSnapshotInfoTx is the same as FindLastSnapshotInfo, but binds to
+// the given sot (a Snapshot or Txn) instead of opening its own.
+func (d *DB) FindLastSnapshotInfoTx(
+	ctx context.Context,
+	sot db.SnapshotOrTxn,
+	docID db.ID,
+) (*db.SnapshotInfo, error) {
+	txn, owns, err := d.boundTxn(sot, false)
+	if err != nil {
+		return nil, err
+	}
+	if owns {
+		defer txn.Abort()
+	}
 
 	iterator, err := txn.ReverseLowerBound(
 		tblSnapshots,
@@ -390,6 +532,373 @@ func (d *DB) FindLastSnapshotInfo(
 	return raw.(*db.SnapshotInfo), nil
 }
 
+// RestoreDocument restores the given document to the state it had at the
+// target RestorePoint by replaying changes from the nearest preceding
+// snapshot. Unless target.Force is set, the restore fails if any attached
+// client has already synced past the target server sequence. When
+// target.Destructive is set, the change log and snapshots after the target
+// are truncated in place; otherwise the restored state is forked into a new
+// document and the original is left untouched.
+func (d *DB) RestoreDocument(
+	ctx context.Context,
+	docID db.ID,
+	target db.RestorePoint,
+) (*db.DocInfo, error) {
+	txn := d.db.Txn(true)
+	defer txn.Abort()
+
+	targetSeq, err := d.resolveRestoreServerSeq(txn, docID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := txn.First(tblDocuments, "id", docID.String())
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("%s: %w", docID, db.ErrDocumentNotFound)
+	}
+	docInfo := raw.(*db.DocInfo).DeepCopy()
+
+	if !target.Force {
+		if err := d.ensureNoClientAheadOfTarget(txn, docID, targetSeq); err != nil {
+			return nil, err
+		}
+	}
+
+	if !target.Destructive {
+		if existing, err := d.findExistingRestore(txn, docID, targetSeq); err != nil {
+			return nil, err
+		} else if existing != nil {
+			return existing, nil
+		}
+	}
+
+	root, fromSeq, err := d.loadSnapshotRoot(txn, docID, targetSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	changeInfos, err := d.findChangeInfosInTxn(txn, docID, fromSeq+1, targetSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	internalDoc := document.NewInternalDocument(docInfo.Key, root)
+	for _, info := range changeInfos {
+		cn, err := info.ToChange()
+		if err != nil {
+			return nil, err
+		}
+		if err := internalDoc.ApplyChanges(cn); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot, err := converter.ObjectToBytes(internalDoc.RootObject())
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Destructive {
+		if err := d.deleteAfterServerSeq(txn, tblChanges, docID, targetSeq); err != nil {
+			return nil, err
+		}
+		if err := d.deletePrunedSnapshotChunks(txn, docID, targetSeq); err != nil {
+			return nil, err
+		}
+		if err := d.deleteAfterServerSeq(txn, tblSnapshots, docID, targetSeq); err != nil {
+			return nil, err
+		}
+		if err := d.deleteAfterServerSeq(txn, tblSyncedSeqs, docID, targetSeq); err != nil {
+			return nil, err
+		}
+
+		// A previous destructive restore to this same targetSeq may have
+		// already left a snapshot exactly at targetSeq in place (it isn't
+		// touched by the deletes above, which only remove ServerSeq >
+		// targetSeq); replace it instead of inserting a duplicate, so
+		// repeating the same restore is idempotent.
+		if err := d.deleteSnapshotAtServerSeq(txn, docID, targetSeq); err != nil {
+			return nil, err
+		}
+		if err := d.insertChunkedSnapshot(txn, docID, targetSeq, snapshot, db.DefaultSnapshotChunkSize); err != nil {
+			return nil, err
+		}
+
+		docInfo.ServerSeq = targetSeq
+		docInfo.UpdatedAt = gotime.Now()
+		if err := txn.Insert(tblDocuments, docInfo); err != nil {
+			return nil, err
+		}
+
+		txn.Commit()
+		return docInfo, nil
+	}
+
+	owner := target.NewOwner
+	if owner == "" {
+		owner = docInfo.Owner
+	}
+	now := gotime.Now()
+	forked := &db.DocInfo{
+		ID:         newID(),
+		Key:        fmt.Sprintf("%s@restored-%d", docInfo.Key, targetSeq),
+		Owner:      owner,
+		ServerSeq:  targetSeq,
+		CreatedAt:  now,
+		AccessedAt: now,
+	}
+	if err := txn.Insert(tblDocuments, forked); err != nil {
+		return nil, err
+	}
+
+	for _, info := range changeInfos {
+		clone := *info
+		clone.ID = newID()
+		clone.DocID = forked.ID
+		if err := txn.Insert(tblChanges, &clone); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := d.insertChunkedSnapshot(txn, forked.ID, targetSeq, snapshot, db.DefaultSnapshotChunkSize); err != nil {
+		return nil, err
+	}
+
+	if err := txn.Insert(tblDocRestores, &db.RestoreInfo{
+		ID:           newID(),
+		DocID:        forked.ID,
+		RestoredFrom: docID,
+		ServerSeq:    targetSeq,
+		CreatedAt:    now,
+	}); err != nil {
+		return nil, err
+	}
+
+	txn.Commit()
+	return forked, nil
+}
+
+// resolveRestoreServerSeq resolves a RestorePoint to a concrete ServerSeq,
+// looking up the change log by wall-clock time when target.Time is set.
+func (d *DB) resolveRestoreServerSeq(
+	txn *memdb.Txn,
+	docID db.ID,
+	target db.RestorePoint,
+) (uint64, error) {
+	if target.ServerSeq > 0 {
+		return target.ServerSeq, nil
+	}
+	if target.Time.IsZero() {
+		return 0, db.ErrEmptyRestorePoint
+	}
+
+	iterator, err := txn.LowerBound(
+		tblChanges,
+		"doc_id_server_seq",
+		docID.String(),
+		uint64(0),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var serverSeq uint64
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		info := raw.(*db.ChangeInfo)
+		if info.DocID != docID {
+			break
+		}
+		if info.CreatedAt.IsZero() {
+			return 0, fmt.Errorf("change %s: %w", info.ID, db.ErrChangeMissingCreatedAt)
+		}
+		if info.CreatedAt.After(target.Time) {
+			break
+		}
+		serverSeq = info.ServerSeq
+	}
+
+	return serverSeq, nil
+}
+
+// ensureNoClientAheadOfTarget fails if any client attached to docID has
+// already synced past targetSeq.
+func (d *DB) ensureNoClientAheadOfTarget(
+	txn *memdb.Txn,
+	docID db.ID,
+	targetSeq uint64,
+) error {
+	iterator, err := txn.Get(tblClients, "id")
+	if err != nil {
+		return err
+	}
+
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		clientInfo := raw.(*db.ClientInfo)
+		clientDocInfo, ok := clientInfo.Documents[docID]
+		if !ok {
+			continue
+		}
+		if clientDocInfo.ServerSeq > targetSeq {
+			return fmt.Errorf(
+				"client %s synced to %d past target %d: %w",
+				clientInfo.ID, clientDocInfo.ServerSeq, targetSeq, db.ErrClientAttachedAfterTarget,
+			)
+		}
+	}
+
+	return nil
+}
+
+// findExistingRestore returns the previously forked DocInfo for
+// (docID, targetSeq), if RestoreDocument has already been run with this
+// target, so that repeated non-destructive restores are idempotent.
+func (d *DB) findExistingRestore(
+	txn *memdb.Txn,
+	docID db.ID,
+	targetSeq uint64,
+) (*db.DocInfo, error) {
+	iterator, err := txn.Get(tblDocRestores, "restored_from", docID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		info := raw.(*db.RestoreInfo)
+		if info.ServerSeq != targetSeq {
+			continue
+		}
+
+		docRaw, err := txn.First(tblDocuments, "id", info.DocID.String())
+		if err != nil {
+			return nil, err
+		}
+		if docRaw == nil {
+			continue
+		}
+		return docRaw.(*db.DocInfo).DeepCopy(), nil
+	}
+
+	return nil, nil
+}
+
+// loadSnapshotRoot returns the root object of the nearest snapshot at or
+// before targetSeq, along with the ServerSeq it was taken at. It returns a
+// fresh empty root when no snapshot exists yet.
+func (d *DB) loadSnapshotRoot(
+	txn *memdb.Txn,
+	docID db.ID,
+	targetSeq uint64,
+) (*crdt.Object, uint64, error) {
+	iterator, err := txn.ReverseLowerBound(
+		tblSnapshots,
+		"doc_id_server_seq",
+		docID.String(),
+		targetSeq,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if raw := iterator.Next(); raw != nil {
+		if info := raw.(*db.SnapshotInfo); info.DocID == docID {
+			payload, err := d.readSnapshotPayload(txn, info.ID)
+			if err != nil {
+				return nil, 0, err
+			}
+			root, err := converter.BytesToObject(payload)
+			if err != nil {
+				return nil, 0, err
+			}
+			return root, info.ServerSeq, nil
+		}
+	}
+
+	return crdt.NewObject(crdt.NewElementRHT(), time.InitialTicket), 0, nil
+}
+
+// findChangeInfosInTxn returns the changeInfos between two server sequences
+// bound to an already-open transaction.
+func (d *DB) findChangeInfosInTxn(
+	txn *memdb.Txn,
+	docID db.ID,
+	from uint64,
+	to uint64,
+) ([]*db.ChangeInfo, error) {
+	var infos []*db.ChangeInfo
+
+	iterator, err := txn.LowerBound(
+		tblChanges,
+		"doc_id_server_seq",
+		docID.String(),
+		from,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		info := raw.(*db.ChangeInfo)
+		if info.DocID != docID || info.ServerSeq > to {
+			break
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// deleteAfterServerSeq deletes every row of the given table for docID whose
+// ServerSeq is greater than targetSeq.
+func (d *DB) deleteAfterServerSeq(
+	txn *memdb.Txn,
+	table string,
+	docID db.ID,
+	targetSeq uint64,
+) error {
+	iterator, err := txn.LowerBound(
+		table,
+		"doc_id_server_seq",
+		docID.String(),
+		targetSeq+1,
+	)
+	if err != nil {
+		return err
+	}
+
+	var toDelete []interface{}
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		if rowDocID(raw) != docID {
+			break
+		}
+		toDelete = append(toDelete, raw)
+	}
+	for _, raw := range toDelete {
+		if err := txn.Delete(table, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowDocID extracts the DocID of a row from one of the tables indexed by
+// "doc_id_server_seq" (tblChanges, tblSnapshots, tblSyncedSeqs), so
+// deleteAfterServerSeq can stop once the iterator walks off the end of the
+// target document's rows and into the next document's.
+func rowDocID(raw interface{}) db.ID {
+	switch row := raw.(type) {
+	case *db.ChangeInfo:
+		return row.DocID
+	case *db.SnapshotInfo:
+		return row.DocID
+	case *db.SyncedSeqInfo:
+		return row.DocID
+	default:
+		panic(fmt.Sprintf("rowDocID: unsupported row type %T", raw))
+	}
+}
+
 // UpdateAndFindMinSyncedTicket updates the given serverSeq of the given client
 // and returns the min synced ticket.
 func (d *DB) UpdateAndFindMinSyncedTicket(
@@ -398,12 +907,33 @@ func (d *DB) UpdateAndFindMinSyncedTicket(
 	docID db.ID,
 	serverSeq uint64,
 ) (*time.Ticket, error) {
-	if err := d.updateSyncedSeq(clientInfo, docID, serverSeq); err != nil {
+	return d.UpdateAndFindMinSyncedTicketTx(ctx, nil, clientInfo, docID, serverSeq)
+}
+
+// UpdateAndFindMinSyncedTicketTx is the same as UpdateAndFindMinSyncedTicket,
+// but binds to the given sot (a Snapshot or Txn) instead of opening its
+// own, so it can be composed with UpdateClientInfoAfterPushPullTx and
+// CreateChangeInfosTx into a single isolated PushPull. Since it mutates
+// synced seqs, binding it to a Snapshot fails with db.ErrReadOnlyTxn.
+func (d *DB) UpdateAndFindMinSyncedTicketTx(
+	ctx context.Context,
+	sot db.SnapshotOrTxn,
+	clientInfo *db.ClientInfo,
+	docID db.ID,
+	serverSeq uint64,
+) (*time.Ticket, error) {
+	if err := d.updateSyncedSeqTx(sot, clientInfo, docID, serverSeq); err != nil {
 		return nil, err
 	}
 
-	txn := d.db.Txn(false)
-	defer txn.Abort()
+	memTxn, owns, err := d.boundTxn(sot, false)
+	if err != nil {
+		return nil, err
+	}
+	if owns {
+		defer memTxn.Abort()
+	}
+	txn := memTxn
 
 	iterator, err := txn.LowerBound(
 		tblSyncedSeqs,
@@ -429,13 +959,23 @@ func (d *DB) UpdateAndFindMinSyncedTicket(
 	return d.findTicketByServerSeq(txn, docID, syncedSeqInfo.ServerSeq)
 }
 
-func (d *DB) updateSyncedSeq(
+// updateSyncedSeqTx binds to the given sot (a Snapshot or Txn) instead of
+// opening its own. Since it mutates, binding it to a Snapshot fails with
+// db.ErrReadOnlyTxn.
+func (d *DB) updateSyncedSeqTx(
+	sot db.SnapshotOrTxn,
 	clientInfo *db.ClientInfo,
 	docID db.ID,
 	serverSeq uint64,
 ) error {
-	txn := d.db.Txn(true)
-	defer txn.Abort()
+	memTxn, owns, err := d.boundTxn(sot, true)
+	if err != nil {
+		return err
+	}
+	if owns {
+		defer memTxn.Abort()
+	}
+	txn := memTxn
 
 	isAttached, err := clientInfo.IsAttached(docID)
 	if err != nil {
@@ -478,7 +1018,9 @@ func (d *DB) updateSyncedSeq(
 		}
 	}
 
-	txn.Commit()
+	if owns {
+		txn.Commit()
+	}
 	return nil
 }
 
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+// ListClientInfos returns every ClientInfo in the database. To read it
+// within a consistent view shared with other reads, bind it to a
+// db.Snapshot via ListClientInfosTx.
+func (d *DB) ListClientInfos(ctx context.Context) ([]*db.ClientInfo, error) {
+	return d.ListClientInfosTx(ctx, nil)
+}
+
+// ListClientInfosTx is the same as ListClientInfos, but binds to the given
+// sot (a Snapshot or Txn) instead of opening its own.
+func (d *DB) ListClientInfosTx(ctx context.Context, sot db.SnapshotOrTxn) ([]*db.ClientInfo, error) {
+	txn, owns, err := d.boundTxn(sot, false)
+	if err != nil {
+		return nil, err
+	}
+	if owns {
+		defer txn.Abort()
+	}
+
+	iterator, err := txn.Get(tblClients, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []*db.ClientInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		infos = append(infos, raw.(*db.ClientInfo).DeepCopy())
+	}
+	return infos, nil
+}
+
+// ListDocInfos returns every DocInfo in the database whose key has the
+// given prefix. An empty prefix matches every document.
+func (d *DB) ListDocInfos(ctx context.Context, keyPrefix string) ([]*db.DocInfo, error) {
+	return d.ListDocInfosTx(ctx, nil, keyPrefix)
+}
+
+// ListDocInfosTx is the same as ListDocInfos, but binds to the given sot
+// (a Snapshot or Txn) instead of opening its own.
+func (d *DB) ListDocInfosTx(
+	ctx context.Context,
+	sot db.SnapshotOrTxn,
+	keyPrefix string,
+) ([]*db.DocInfo, error) {
+	txn, owns, err := d.boundTxn(sot, false)
+	if err != nil {
+		return nil, err
+	}
+	if owns {
+		defer txn.Abort()
+	}
+
+	iterator, err := txn.Get(tblDocuments, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []*db.DocInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		info := raw.(*db.DocInfo)
+		if keyPrefix != "" && !strings.HasPrefix(info.Key, keyPrefix) {
+			continue
+		}
+		infos = append(infos, info.DeepCopy())
+	}
+	return infos, nil
+}
+
+// ListSnapshotInfos returns every SnapshotInfo recorded for docID, in
+// ascending ServerSeq order. Unlike FindLastSnapshotInfo, this includes
+// intermediate snapshots, e.g. for callers like backup.Dump that need the
+// document's full snapshot history rather than just its latest state.
+func (d *DB) ListSnapshotInfos(ctx context.Context, docID db.ID) ([]*db.SnapshotInfo, error) {
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	iterator, err := txn.Get(tblSnapshots, "doc_id_server_seq_prefix", docID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []*db.SnapshotInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		infos = append(infos, raw.(*db.SnapshotInfo))
+	}
+	return infos, nil
+}
+
+// ListSyncedSeqInfos returns every SyncedSeqInfo recorded for docID.
+func (d *DB) ListSyncedSeqInfos(ctx context.Context, docID db.ID) ([]*db.SyncedSeqInfo, error) {
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	iterator, err := txn.Get(tblSyncedSeqs, "doc_id_server_seq_prefix", docID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []*db.SyncedSeqInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		infos = append(infos, raw.(*db.SyncedSeqInfo))
+	}
+	return infos, nil
+}
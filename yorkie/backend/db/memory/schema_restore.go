@@ -0,0 +1,44 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import "github.com/hashicorp/go-memdb"
+
+// tblDocRestores stores RestoreInfo rows that track which documents were
+// forked off of an earlier point in another document's change log.
+const tblDocRestores = "restores"
+
+func init() {
+	schema.Tables[tblDocRestores] = &memdb.TableSchema{
+		Name: tblDocRestores,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:    "id",
+				Unique:  true,
+				Indexer: &memdb.StringFieldIndex{Field: "ID"},
+			},
+			"doc_id": {
+				Name:    "doc_id",
+				Indexer: &memdb.StringFieldIndex{Field: "DocID"},
+			},
+			"restored_from": {
+				Name:    "restored_from",
+				Indexer: &memdb.StringFieldIndex{Field: "RestoredFrom"},
+			},
+		},
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import "github.com/hashicorp/go-memdb"
+
+// tblSnapshotChunks stores SnapshotChunkInfo rows, keyed by the
+// SnapshotInfo they belong to and their position within it. The chunks'
+// total size, count, and combined hash live on the owning SnapshotInfo row
+// itself rather than a separate table.
+const tblSnapshotChunks = "snapshot_chunks"
+
+func init() {
+	schema.Tables[tblSnapshotChunks] = &memdb.TableSchema{
+		Name: tblSnapshotChunks,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:    "id",
+				Unique:  true,
+				Indexer: &memdb.StringFieldIndex{Field: "ID"},
+			},
+			"snapshot_id_index": {
+				Name:   "snapshot_id_index",
+				Unique: true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{Field: "SnapshotID"},
+						&memdb.UintFieldIndex{Field: "Index"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+// splitSnapshotPayload splits payload into chunkSize-sized pieces and
+// computes the TotalSize/NumChunks/Hash metadata that gets attached to the
+// owning SnapshotInfo. chunkSize <= 0 falls back to
+// db.DefaultSnapshotChunkSize.
+func splitSnapshotPayload(payload []byte, chunkSize int) (chunks [][]byte, totalSize int64, hash string) {
+	if chunkSize <= 0 {
+		chunkSize = db.DefaultSnapshotChunkSize
+	}
+
+	for offset := 0; offset < len(payload); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunks = append(chunks, payload[offset:end])
+	}
+	if len(chunks) == 0 {
+		chunks = [][]byte{{}}
+	}
+
+	sum := sha256.Sum256(payload)
+	return chunks, int64(len(payload)), hex.EncodeToString(sum[:])
+}
+
+// writeSnapshotChunks inserts the given already-split chunks as
+// SnapshotChunkInfo rows for snapshotID, bound to the given transaction. The
+// owning SnapshotInfo row, with its TotalSize/NumChunks/Hash already set, is
+// expected to have been inserted by the caller beforehand.
+func (d *DB) writeSnapshotChunks(txn *memdb.Txn, snapshotID db.ID, chunks [][]byte) error {
+	for i, chunk := range chunks {
+		if err := txn.Insert(tblSnapshotChunks, &db.SnapshotChunkInfo{
+			ID:         newID(),
+			SnapshotID: snapshotID,
+			Index:      uint32(i),
+			Data:       chunk,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSnapshotChunksTx returns the owning SnapshotInfo and ordered,
+// gap-checked chunks for the given snapshotID, bound to an already-open
+// transaction.
+func (d *DB) loadSnapshotChunksTx(
+	txn *memdb.Txn,
+	snapshotID db.ID,
+) (*db.SnapshotInfo, []*db.SnapshotChunkInfo, error) {
+	raw, err := txn.First(tblSnapshots, "id", snapshotID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	if raw == nil {
+		return nil, nil, fmt.Errorf("%s: %w", snapshotID, db.ErrSnapshotCorrupt)
+	}
+	info := raw.(*db.SnapshotInfo)
+
+	iterator, err := txn.Get(tblSnapshotChunks, "snapshot_id_index_prefix", snapshotID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var chunks []*db.SnapshotChunkInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		chunks = append(chunks, raw.(*db.SnapshotChunkInfo))
+	}
+	if uint32(len(chunks)) != info.NumChunks {
+		return nil, nil, fmt.Errorf(
+			"%s: want %d chunks, found %d: %w",
+			snapshotID, info.NumChunks, len(chunks), db.ErrSnapshotCorrupt,
+		)
+	}
+	for i, chunk := range chunks {
+		if chunk.Index != uint32(i) {
+			return nil, nil, fmt.Errorf("%s: missing chunk %d: %w", snapshotID, i, db.ErrSnapshotCorrupt)
+		}
+	}
+
+	return info, chunks, nil
+}
+
+// readSnapshotPayload reassembles a snapshot's full payload, bound to an
+// already-open transaction, and verifies it against the recorded Hash.
+// Prefer OpenSnapshot for large snapshots, since this buffers the whole
+// payload in memory.
+func (d *DB) readSnapshotPayload(txn *memdb.Txn, snapshotID db.ID) ([]byte, error) {
+	info, chunks, err := d.loadSnapshotChunksTx(txn, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, chunk := range chunks {
+		buf.Write(chunk.Data)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	if hex.EncodeToString(sum[:]) != info.Hash {
+		return nil, fmt.Errorf("%s: %w", snapshotID, db.ErrSnapshotCorrupt)
+	}
+	return buf.Bytes(), nil
+}
+
+// deletePrunedSnapshotChunks deletes the chunks of every SnapshotInfo of
+// docID with ServerSeq > targetSeq, bound to an already-open transaction.
+// Callers are expected to delete the pruned SnapshotInfo rows themselves
+// afterwards.
+func (d *DB) deletePrunedSnapshotChunks(txn *memdb.Txn, docID db.ID, targetSeq uint64) error {
+	iterator, err := txn.LowerBound(
+		tblSnapshots,
+		"doc_id_server_seq",
+		docID.String(),
+		targetSeq+1,
+	)
+	if err != nil {
+		return err
+	}
+
+	var pruned []*db.SnapshotInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		info := raw.(*db.SnapshotInfo)
+		if info.DocID != docID {
+			break
+		}
+		pruned = append(pruned, info)
+	}
+
+	for _, info := range pruned {
+		if _, err := txn.DeleteAll(tblSnapshotChunks, "snapshot_id_index_prefix", info.ID.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteSnapshotAtServerSeq deletes the chunks and row of any SnapshotInfo
+// of docID with ServerSeq exactly targetSeq, bound to an already-open
+// transaction. It's used before writing a fresh snapshot at targetSeq, so
+// that rerunning a destructive restore with the same target replaces the
+// snapshot it previously wrote rather than leaving a duplicate alongside it.
+func (d *DB) deleteSnapshotAtServerSeq(txn *memdb.Txn, docID db.ID, targetSeq uint64) error {
+	iterator, err := txn.Get(
+		tblSnapshots,
+		"doc_id_server_seq",
+		docID.String(),
+		targetSeq,
+	)
+	if err != nil {
+		return err
+	}
+
+	var existing []*db.SnapshotInfo
+	for raw := iterator.Next(); raw != nil; raw = iterator.Next() {
+		existing = append(existing, raw.(*db.SnapshotInfo))
+	}
+
+	for _, info := range existing {
+		if _, err := txn.DeleteAll(tblSnapshotChunks, "snapshot_id_index_prefix", info.ID.String()); err != nil {
+			return err
+		}
+		if err := txn.Delete(tblSnapshots, info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindSnapshotChunks returns the chunks of the given snapshot in order.
+func (d *DB) FindSnapshotChunks(
+	ctx context.Context,
+	snapshotID db.ID,
+) ([]*db.SnapshotChunkInfo, error) {
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	_, chunks, err := d.loadSnapshotChunksTx(txn, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+// OpenSnapshot returns a reader that lazily streams the given snapshot's
+// payload chunk by chunk, so consumers don't need to hold the whole
+// snapshot in memory. The reassembled payload's hash is verified once the
+// reader reaches EOF; a mismatch surfaces as db.ErrSnapshotCorrupt from the
+// final Read call.
+func (d *DB) OpenSnapshot(ctx context.Context, snapshotID db.ID) (io.ReadCloser, error) {
+	txn := d.db.Txn(false)
+	defer txn.Abort()
+
+	info, chunks, err := d.loadSnapshotChunksTx(txn, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotReader{
+		info:   info,
+		chunks: chunks,
+		hasher: sha256.New(),
+	}, nil
+}
+
+// snapshotReader streams a snapshot's chunks in order and verifies their
+// combined hash against the owning SnapshotInfo's recorded Hash once fully
+// read.
+type snapshotReader struct {
+	info   *db.SnapshotInfo
+	chunks []*db.SnapshotChunkInfo
+	idx    int
+	buf    []byte
+	hasher hash.Hash
+}
+
+// Read implements io.Reader.
+func (r *snapshotReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.idx >= len(r.chunks) {
+			if hex.EncodeToString(r.hasher.Sum(nil)) != r.info.Hash {
+				return 0, fmt.Errorf("%s: %w", r.info.ID, db.ErrSnapshotCorrupt)
+			}
+			return 0, io.EOF
+		}
+		r.buf = r.chunks[r.idx].Data
+		r.idx++
+	}
+
+	n := copy(p, r.buf)
+	r.hasher.Write(p[:n])
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close implements io.Closer.
+func (r *snapshotReader) Close() error {
+	return nil
+}
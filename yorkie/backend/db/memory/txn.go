@@ -0,0 +1,87 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+)
+
+// snapshot is a memdb-backed, read-only db.Snapshot.
+type snapshot struct {
+	txn *memdb.Txn
+}
+
+// IsReadOnly implements db.SnapshotOrTxn.
+func (s *snapshot) IsReadOnly() bool { return true }
+
+// Abort releases the snapshot.
+func (s *snapshot) Abort() {
+	s.txn.Abort()
+}
+
+// txn is a memdb-backed, read/write db.Txn.
+type txn struct {
+	txn *memdb.Txn
+}
+
+// IsReadOnly implements db.SnapshotOrTxn.
+func (t *txn) IsReadOnly() bool { return false }
+
+// Commit makes the Txn's writes visible to new Snapshots and Txns.
+func (t *txn) Commit() {
+	t.txn.Commit()
+}
+
+// Abort discards the Txn's writes.
+func (t *txn) Abort() {
+	t.txn.Abort()
+}
+
+// NewSnapshot returns a consistent read-only view of the database.
+func (d *DB) NewSnapshot(ctx context.Context) (db.Snapshot, error) {
+	return &snapshot{txn: d.db.Txn(false)}, nil
+}
+
+// BeginTxn begins a read/write view of the database.
+func (d *DB) BeginTxn(ctx context.Context) (db.Txn, error) {
+	return &txn{txn: d.db.Txn(true)}, nil
+}
+
+// boundTxn resolves a db.SnapshotOrTxn to the underlying memdb.Txn. When
+// sot is nil, it opens a new implicit transaction that the caller owns and
+// must Commit (if write) or Abort itself; the returned owns flag tells the
+// caller which case it got.
+func (d *DB) boundTxn(sot db.SnapshotOrTxn, write bool) (memTxn *memdb.Txn, owns bool, err error) {
+	switch v := sot.(type) {
+	case nil:
+		return d.db.Txn(write), true, nil
+	case *txn:
+		return v.txn, false, nil
+	case *snapshot:
+		if write {
+			return nil, false, db.ErrReadOnlyTxn
+		}
+		return v.txn, false, nil
+	default:
+		return nil, false, fmt.Errorf("%T: unknown SnapshotOrTxn implementation", sot)
+	}
+}
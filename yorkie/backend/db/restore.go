@@ -0,0 +1,90 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"errors"
+	gotime "time"
+)
+
+var (
+	// ErrClientAttachedAfterTarget is returned by RestoreDocument when an
+	// attached client has already synced past the restore target and
+	// RestorePoint.Force was not set.
+	ErrClientAttachedAfterTarget = errors.New("client already synced past restore target")
+
+	// ErrEmptyRestorePoint is returned by RestoreDocument when neither
+	// ServerSeq nor Time is set on the given RestorePoint, so there is no
+	// target to resolve.
+	ErrEmptyRestorePoint = errors.New("restore point has neither ServerSeq nor Time set")
+
+	// ErrChangeMissingCreatedAt is returned by RestoreDocument when
+	// resolving a time-based RestorePoint encounters a ChangeInfo with a
+	// zero CreatedAt, which would make the change log's chronological
+	// order unreliable. This can only happen for changes written before
+	// ChangeInfo.CreatedAt started being recorded.
+	ErrChangeMissingCreatedAt = errors.New("change is missing CreatedAt, needed to resolve a time-based restore point")
+)
+
+// RestorePoint specifies the point in a document's change log to restore
+// to. Exactly one of ServerSeq or Time should be set; when Time is set, the
+// target is resolved to the ServerSeq that was current at that wall-clock
+// time.
+type RestorePoint struct {
+	// ServerSeq restores the document to this exact server sequence.
+	ServerSeq uint64
+
+	// Time restores the document to the server sequence that was current
+	// at this point in time. Ignored if ServerSeq is non-zero.
+	Time gotime.Time
+
+	// Force allows the restore to proceed even though an attached client
+	// has already synced past the target server sequence.
+	Force bool
+
+	// Destructive truncates the document's change log and snapshots after
+	// the target server sequence in place, instead of forking a new
+	// document. History past the target point is permanently discarded.
+	Destructive bool
+
+	// NewOwner is the owner recorded on the forked document. Ignored if
+	// Destructive is set. Defaults to the owner of the source document.
+	NewOwner ID
+}
+
+// RestoreInfo records that a document was produced by restoring another
+// document to an earlier point in its change log. Provenance lives here
+// rather than on DocInfo itself, since DocInfo is shared with every other
+// code path that creates a document and has no notion of a restore source;
+// RestoreInfo.RestoredFrom is indexed, so a caller can always look up every
+// fork of a given source document, which is what RestoreDocument's
+// idempotency check (finding the fork already made for a given target) and
+// any future "list restores of this document" API both need.
+type RestoreInfo struct {
+	ID ID
+
+	// DocID is the forked document this RestoreInfo describes.
+	DocID ID
+
+	// RestoredFrom is the source document DocID was forked from. Indexed,
+	// so RestoreDocument can look up prior forks of a source document to
+	// stay idempotent.
+	RestoredFrom ID
+
+	ServerSeq uint64
+	CreatedAt gotime.Time
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import "errors"
+
+// DefaultSnapshotChunkSize is the size a snapshot's serialized bytes are
+// split into when none is configured, chosen to stay well under MongoDB's
+// 16 MiB BSON document limit.
+const DefaultSnapshotChunkSize = 4 * 1024 * 1024 // 4 MiB
+
+// ErrSnapshotCorrupt is returned when a chunked snapshot fails its
+// integrity check on read: a chunk is missing, out of order, or the
+// reassembled payload doesn't match the hash recorded at write time.
+var ErrSnapshotCorrupt = errors.New("snapshot is corrupt")
+
+// SnapshotChunkInfo is one chunk of a SnapshotInfo's serialized bytes,
+// stored as its own row so a single snapshot can exceed a backend's
+// per-document size limit.
+type SnapshotChunkInfo struct {
+	ID         ID
+	SnapshotID ID
+	Index      uint32
+	Data       []byte
+}
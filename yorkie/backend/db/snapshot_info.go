@@ -0,0 +1,48 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	gotime "time"
+)
+
+// SnapshotInfo is a snapshot of a document's root object at a given
+// ServerSeq. Its payload is split across SnapshotChunkInfo rows rather than
+// held in one field, so a single snapshot can exceed a backend's
+// per-document size limit; Snapshot is left unset and TotalSize, NumChunks,
+// and Hash describe the chunked payload instead. Read the payload back
+// with DB.OpenSnapshot (or DB.FindSnapshotChunks for the raw rows); Hash is
+// verified against the reassembled bytes as they're read.
+type SnapshotInfo struct {
+	ID        ID
+	DocID     ID
+	ServerSeq uint64
+	CreatedAt gotime.Time
+
+	// Snapshot holds the raw payload only for snapshots predating chunked
+	// storage. New writes go through TotalSize/NumChunks/Hash below and
+	// leave this nil.
+	Snapshot []byte
+
+	// TotalSize is the size in bytes of the reassembled chunked payload.
+	TotalSize int64
+	// NumChunks is the number of SnapshotChunkInfo rows the payload was
+	// split into.
+	NumChunks uint32
+	// Hash is the hex-encoded SHA-256 of the reassembled chunked payload.
+	Hash string
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import "errors"
+
+// ErrReadOnlyTxn is returned when a mutating call is issued against a
+// Snapshot instead of a Txn.
+var ErrReadOnlyTxn = errors.New("write attempted on a read-only snapshot")
+
+// SnapshotOrTxn is implemented by both Snapshot and Txn, so read paths can
+// be bound to either a consistent read-only view or a read/write
+// transaction. Passing nil to a *Tx method keeps the previous behavior of
+// opening and closing an implicit transaction for that single call.
+type SnapshotOrTxn interface {
+	// IsReadOnly reports whether a mutating call bound to this view should
+	// be rejected with ErrReadOnlyTxn. Exported so that implementations
+	// backing SnapshotOrTxn can live outside package db (e.g. in the
+	// memory and mongo backends).
+	IsReadOnly() bool
+}
+
+// Snapshot is a read-only, consistent view of the database. Every read
+// bound to the same Snapshot observes the same point-in-time state,
+// regardless of writes that commit after the snapshot was taken.
+type Snapshot interface {
+	SnapshotOrTxn
+
+	// Abort releases the snapshot.
+	Abort()
+}
+
+// Txn is a read/write view of the database. Operations bound to the same
+// Txn are isolated from other Txns and Snapshots until Commit is called,
+// which lets callers like packs.PushPull compose several reads and writes
+// into one atomic, isolated unit of work.
+type Txn interface {
+	SnapshotOrTxn
+
+	// Commit makes the Txn's writes visible to new Snapshots and Txns.
+	Commit()
+
+	// Abort discards the Txn's writes.
+	Abort()
+}
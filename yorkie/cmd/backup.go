@@ -0,0 +1,84 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db/backup"
+)
+
+// NewBackupCmd creates a new cobra.Command for the backup command. source is
+// the database to export, opened the same way the running server opens its
+// own backend.
+func NewBackupCmd(source db.DB) *cobra.Command {
+	var (
+		output        string
+		keyPrefix     string
+		fromServerSeq uint64
+		toServerSeq   uint64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Dump the database to a portable backup archive",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			return backup.Dump(context.Background(), source, out, backup.DumpOptions{
+				KeyPrefix:     keyPrefix,
+				FromServerSeq: fromServerSeq,
+				ToServerSeq:   toServerSeq,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&output,
+		"output",
+		"backup.tar.gz",
+		"output path for the backup archive",
+	)
+	cmd.Flags().StringVar(
+		&keyPrefix,
+		"key-prefix",
+		"",
+		"only back up documents whose key has this prefix",
+	)
+	cmd.Flags().Uint64Var(
+		&fromServerSeq,
+		"from-server-seq",
+		0,
+		"only back up changes from this server sequence onward",
+	)
+	cmd.Flags().Uint64Var(
+		&toServerSeq,
+		"to-server-seq",
+		0,
+		"only back up changes up to this server sequence (0 means each document's current sequence)",
+	)
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2021 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yorkie-team/yorkie/yorkie/backend/db"
+	"github.com/yorkie-team/yorkie/yorkie/backend/db/backup"
+)
+
+// NewRestoreCmd creates a new cobra.Command for the restore command. dest is
+// the database to import into, opened the same way the running server opens
+// its own backend.
+func NewRestoreCmd(dest db.DB) *cobra.Command {
+	var overwrite bool
+
+	cmd := &cobra.Command{
+		Use:   "restore [archive]",
+		Short: "Restore the database from a portable backup archive",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+
+			return backup.Restore(context.Background(), dest, in, backup.RestoreOptions{
+				Overwrite: overwrite,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&overwrite,
+		"overwrite",
+		false,
+		"replace documents that already exist in the destination database",
+	)
+
+	return cmd
+}